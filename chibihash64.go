@@ -1,81 +1,53 @@
 package chibihash
 
-// load64le 从字节切片中以小端序加载一个uint64值
-func load64le(p []byte) uint64 {
-	return uint64(p[0]) |
-		uint64(p[1])<<8 |
-		uint64(p[2])<<16 |
-		uint64(p[3])<<24 |
-		uint64(p[4])<<32 |
-		uint64(p[5])<<40 |
-		uint64(p[6])<<48 |
-		uint64(p[7])<<56
-}
-
-// Hash64 计算输入数据的64位哈希值
-// key: 输入数据
-// seed: 哈希种子
-func Hash64(key []byte, seed uint64) uint64 {
-	k := key
-	l := len(key)
-
-	const (
-		P1 = uint64(0x2B7E151628AED2A5)
-		P2 = uint64(0x9E3793492EEDC3F7)
-		P3 = uint64(0x3243F6A8885A308D)
-	)
-
-	h := [4]uint64{P1, P2, P3, seed}
+const (
+	chibiP1 = uint64(0x2B7E151628AED2A5)
+	chibiP2 = uint64(0x9E3793492EEDC3F7)
+	chibiP3 = uint64(0x3243F6A8885A308D)
+)
 
-	// 主循环: 每次处理32字节
-	for l >= 32 {
-		for i := 0; i < 4; i++ {
-			lane := load64le(k[i*8:])
-			h[i] ^= lane
-			h[i] *= P1
-			h[(i+1)&3] ^= ((lane << 40) | (lane >> 24))
-		}
-		l -= 32
-		k = k[32:]
-	}
+// tailMix 在主循环结束后，把总长度信息和不足 32 字节的尾部数据混入四个 lane 中。
+// totalLen 是整条消息的长度（而非 tail 的长度），h 以值传递，不会修改调用方持有的状态。
+func tailMix(h [4]uint64, totalLen uint64, tail []byte) [4]uint64 {
+	k := tail
+	l := len(tail)
 
-	// 处理长度信息
-	h[0] += (uint64(len(key)) << 32) | (uint64(len(key)) >> 32)
+	h[0] += (totalLen << 32) | (totalLen >> 32)
 
-	// 处理剩余的单个字节
 	if l&1 != 0 {
 		h[0] ^= uint64(k[0])
 		l--
 		k = k[1:]
 	}
 
-	// 处理第一个哈希槽
-	h[0] *= P2
+	h[0] *= chibiP2
 	h[0] ^= h[0] >> 31
 
-	// 处理剩余的完整8字节块
 	for i := 1; l >= 8; i++ {
 		h[i] ^= load64le(k)
-		h[i] *= P2
+		h[i] *= chibiP2
 		h[i] ^= h[i] >> 31
 		l -= 8
 		k = k[8:]
 	}
 
-	// 处理剩余的2字节块
 	for i := 0; l > 0; i++ {
 		if l >= 2 {
 			h[i] ^= uint64(k[0]) | uint64(k[1])<<8
 		} else {
 			h[i] ^= uint64(k[0])
 		}
-		h[i] *= P3
+		h[i] *= chibiP3
 		h[i] ^= h[i] >> 31
 		l -= 2
 		k = k[2:]
 	}
 
-	// 最终混合
+	return h
+}
+
+// finalMix64 是原始的 chibihash 最终混合步骤，产出 Hash64/Sum64 返回的 64 位结果。
+func finalMix64(seed uint64, h [4]uint64) uint64 {
 	x := seed
 	x ^= h[0] * ((h[2] >> 32) | 1)
 	x ^= h[1] * ((h[3] >> 32) | 1)
@@ -91,3 +63,20 @@ func Hash64(key []byte, seed uint64) uint64 {
 
 	return x
 }
+
+// Hash64 计算输入数据的64位哈希值
+// key: 输入数据
+// seed: 哈希种子
+func Hash64(key []byte, seed uint64) uint64 {
+	return hash64With(mainLoop, key, seed)
+}
+
+// hash64With 和 Hash64 一样，但主循环的实现可以替换，用来在
+// BenchmarkHash64 里同时对比 mainLoop（amd64/arm64 下是汇编实现）
+// 和 mainLoopPureGo 的速度，而不需要用 -tags purego 重新构建整个二进制。
+func hash64With(loop func(h *[4]uint64, k []byte) []byte, key []byte, seed uint64) uint64 {
+	h := [4]uint64{chibiP1, chibiP2, chibiP3, seed}
+	tail := loop(&h, key)
+	h = tailMix(h, uint64(len(key)), tail)
+	return finalMix64(seed, h)
+}
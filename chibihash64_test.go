@@ -134,22 +134,34 @@ func abs(x int) int {
 	return x
 }
 
+// BenchmarkHash64 覆盖一系列输入长度，并且对每个长度都同时跑 mainLoop
+// （amd64/arm64 上是 hash_amd64.s/hash_arm64.s 里的汇编实现，其他架构下
+// 和 purego 等价）和 mainLoopPureGo（不带构建标签、始终是纯 Go 实现），
+// 这样汇编带来的提升单次运行就能直接看到，不需要用 -tags purego 重新构建。
 func BenchmarkHash64(b *testing.B) {
 	sizes := []int{8, 16, 32, 64, 128, 256, 512, 1024, 4096}
+	impls := []struct {
+		name string
+		loop func(h *[4]uint64, k []byte) []byte
+	}{
+		{"default", mainLoop},
+		{"purego", mainLoopPureGo},
+	}
 
 	for _, size := range sizes {
-		b.Run(fmt.Sprintf("size-%d", size), func(b *testing.B) {
-			data := make([]byte, size)
-			rand.Read(data)
-			seed := uint64(0x12345678)
-
-			b.ResetTimer()
-			b.SetBytes(int64(size))
-
-			for i := 0; i < b.N; i++ {
-				Hash64(data, seed)
-			}
-		})
+		data := make([]byte, size)
+		rand.Read(data)
+		seed := uint64(0x12345678)
+
+		for _, impl := range impls {
+			b.Run(fmt.Sprintf("size-%d/%s", size, impl.name), func(b *testing.B) {
+				b.SetBytes(int64(size))
+
+				for i := 0; i < b.N; i++ {
+					hash64With(impl.loop, data, seed)
+				}
+			})
+		}
 	}
 }
 
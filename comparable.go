@@ -0,0 +1,249 @@
+package chibihash
+
+import (
+	"encoding/binary"
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// typeInfo 缓存了某个类型在用 WriteComparable 哈希时需要的布局信息，
+// 这样同一个类型在被反复哈希时只需要做一次 reflect 解析。
+type typeInfo struct {
+	kind  reflect.Kind
+	size  uintptr
+	rtype reflect.Type
+
+	fields []fieldInfo // kind == Struct
+	elem   *typeInfo   // kind == Array || kind == Ptr
+	length int         // kind == Array
+}
+
+// fieldInfo 描述结构体中的一个字段：在结构体中的字节偏移量，以及字段类型的布局信息。
+type fieldInfo struct {
+	offset uintptr
+	info   *typeInfo
+}
+
+// typeInfoCache 把 reflect.Type 映射到对应的、已经完整构建好的 typeInfo，
+// 按类型缓存。typeInfoMu 同时保护 typeInfoCache 的读写。
+var (
+	typeInfoMu    sync.RWMutex
+	typeInfoCache = make(map[reflect.Type]*typeInfo)
+)
+
+// typeInfoFor 返回 t 对应的 typeInfo，首次遇到某个类型时才会构建并缓存。
+//
+// 一个类型及其可达的全部子类型在加锁状态下一次性构建完毕，只有全部构建
+// 完成之后才会发布到 typeInfoCache 里，因此其他 goroutine 不可能从缓存里
+// 读到一个字段还没填完的 typeInfo。自引用类型（例如链表节点里指向同类型
+// 的指针字段）通过本次构建过程中的局部 building 表来打破递归，而不是靠
+// 提前发布到全局缓存。
+func typeInfoFor(t reflect.Type) *typeInfo {
+	typeInfoMu.RLock()
+	info, ok := typeInfoCache[t]
+	typeInfoMu.RUnlock()
+	if ok {
+		return info
+	}
+
+	typeInfoMu.Lock()
+	defer typeInfoMu.Unlock()
+
+	// 双重检查：等待写锁期间，可能已经有别的 goroutine 构建完了。
+	if info, ok := typeInfoCache[t]; ok {
+		return info
+	}
+
+	building := make(map[reflect.Type]*typeInfo)
+	info = buildTypeInfo(t, building)
+	for bt, bi := range building {
+		typeInfoCache[bt] = bi
+	}
+	return info
+}
+
+// buildTypeInfo 递归构建 t 的布局信息，building 记录本次构建过程中
+// 已经创建（但可能还未填完）的 typeInfo，用来让自引用类型的递归能够终止。
+// 调用方必须持有 typeInfoMu 的写锁。
+func buildTypeInfo(t reflect.Type, building map[reflect.Type]*typeInfo) *typeInfo {
+	if info, ok := building[t]; ok {
+		return info
+	}
+
+	info := &typeInfo{kind: t.Kind(), size: t.Size(), rtype: t}
+	building[t] = info
+
+	switch t.Kind() {
+	case reflect.Struct:
+		n := t.NumField()
+		info.fields = make([]fieldInfo, n)
+		for i := 0; i < n; i++ {
+			f := t.Field(i)
+			info.fields[i] = fieldInfo{offset: f.Offset, info: buildTypeInfo(f.Type, building)}
+		}
+	case reflect.Array:
+		info.elem = buildTypeInfo(t.Elem(), building)
+		info.length = t.Len()
+	case reflect.Ptr:
+		info.elem = buildTypeInfo(t.Elem(), building)
+	}
+
+	return info
+}
+
+// writeState 携带遍历单次 WriteComparable 调用时需要的可变状态：目标 Hasher，
+// 以及当前递归路径上已经访问过的指针地址，用来在数据本身存在环（而不仅仅是
+// 类型自引用）时及时停止，不让 writeValue 无限递归下去。
+type writeState struct {
+	h       *Hasher
+	visited map[unsafe.Pointer]struct{}
+}
+
+// writeLenPrefixedString 先写入 s 的长度（8 字节小端序），再写入 s 本身。在内容
+// 前面加一个定长的长度前缀，相邻的变长字段才不会在拼接后产生歧义：比如结构体
+// {A, B string} 取 A="ab", B="cd" 和 A="a", B="bcd"，如果只是把字节依次拼接，
+// 两者都会写出同样的字节流 "abcd"，在任何种子下都会得到相同的哈希。
+func writeLenPrefixedString(h *Hasher, s string) {
+	var lenBuf [8]byte
+	binary.LittleEndian.PutUint64(lenBuf[:], uint64(len(s)))
+	h.Write(lenBuf[:])
+	h.WriteString(s)
+}
+
+// writeValue 把 p 处、由 info 描述的值写入 st.h，不哈希结构体字段之间的填充字节。
+func writeValue(st *writeState, p unsafe.Pointer, info *typeInfo) {
+	switch info.kind {
+	case reflect.String:
+		writeLenPrefixedString(st.h, *(*string)(p))
+
+	case reflect.Struct:
+		for _, f := range info.fields {
+			writeValue(st, unsafe.Pointer(uintptr(p)+f.offset), f.info)
+		}
+
+	case reflect.Array:
+		if info.length == 0 {
+			return
+		}
+		elemSize := info.elem.size
+		for i := 0; i < info.length; i++ {
+			writeValue(st, unsafe.Pointer(uintptr(p)+uintptr(i)*elemSize), info.elem)
+		}
+
+	case reflect.Ptr:
+		ptr := *(*unsafe.Pointer)(p)
+		if ptr == nil {
+			st.h.Write([]byte{0})
+			return
+		}
+		if _, ok := st.visited[ptr]; ok {
+			// 沿着当前递归路径已经见过这个地址：数据本身成环（比如双向链表
+			// 或者带 parent 指针的树），写一个哨兵字节后停止，而不是无限递归
+			// 直到栈溢出崩溃整个进程。
+			st.h.Write([]byte{2})
+			return
+		}
+		st.h.Write([]byte{1})
+		st.visited[ptr] = struct{}{}
+		writeValue(st, ptr, info.elem)
+		delete(st.visited, ptr)
+
+	case reflect.Interface:
+		dyn := reflect.NewAt(info.rtype, p).Elem().Elem()
+		writeInterfaceValue(st, dyn)
+
+	case reflect.Float32:
+		// 先规整 -0.0 → +0.0 再写字节，否则 -0.0 == +0.0 但哈希值不同，
+		// 违反 Comparable 自己文档里 “== 相等则哈希相等” 的保证。
+		f := *(*float32)(p)
+		if f == 0 {
+			f = 0
+		}
+		st.h.Write(unsafe.Slice((*byte)(unsafe.Pointer(&f)), 4))
+
+	case reflect.Float64:
+		f := *(*float64)(p)
+		if f == 0 {
+			f = 0
+		}
+		st.h.Write(unsafe.Slice((*byte)(unsafe.Pointer(&f)), 8))
+
+	case reflect.Complex64:
+		c := *(*complex64)(p)
+		re, im := real(c), imag(c)
+		if re == 0 {
+			re = 0
+		}
+		if im == 0 {
+			im = 0
+		}
+		c = complex(re, im)
+		st.h.Write(unsafe.Slice((*byte)(unsafe.Pointer(&c)), 8))
+
+	case reflect.Complex128:
+		c := *(*complex128)(p)
+		re, im := real(c), imag(c)
+		if re == 0 {
+			re = 0
+		}
+		if im == 0 {
+			im = 0
+		}
+		c = complex(re, im)
+		st.h.Write(unsafe.Slice((*byte)(unsafe.Pointer(&c)), 16))
+
+	default:
+		// 固定大小的基础类型：Bool/Int*/Uint*。
+		st.h.Write(unsafe.Slice((*byte)(p), info.size))
+	}
+}
+
+// writeInterfaceValue 哈希一个接口变量当前持有的动态值。动态值通过
+// reflect.New 拷贝到一块可寻址的内存上，再复用 writeValue 的快速路径。
+func writeInterfaceValue(st *writeState, v reflect.Value) {
+	if !v.IsValid() {
+		st.h.Write([]byte{0})
+		return
+	}
+	st.h.Write([]byte{1})
+
+	cp := reflect.New(v.Type()).Elem()
+	cp.Set(v)
+	writeValue(st, unsafe.Pointer(cp.UnsafeAddr()), typeInfoFor(v.Type()))
+}
+
+// WriteComparable 把可比较值 v 的内容写入 h，效果等价于把 v 的每一个字段按声明
+// 顺序逐一写入，但跳过结构体字段之间的内存填充字节（直接对结构体做
+// unsafe.Pointer 整体写入会把填充字节也哈希进去，这是使用 binary.Write
+// 之类的方式手工序列化可比较类型时很容易踩到的坑），并且在字符串等变长
+// 字段前面写入长度，避免相邻变长字段的内容拼接后产生歧义。
+//
+// 支持的 v 的种类：布尔值、各种整数/浮点数/复数、字符串、数组、结构体、
+// 指针（哈希指向的值，nil 指针单独处理）、接口（哈希其动态值）。指针如果
+// 通过数据本身的环（而不是类型定义上的自引用）绕回了当前正在遍历的某个
+// 地址，会在那里停止，不会死循环。
+func WriteComparable[T comparable](h *Hasher, v T) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		// v 是某个接口类型 T 的 nil 值，没有动态值可写。
+		return
+	}
+
+	cp := reflect.New(rv.Type()).Elem()
+	cp.Set(rv)
+	st := &writeState{h: h, visited: make(map[unsafe.Pointer]struct{})}
+	writeValue(st, unsafe.Pointer(cp.UnsafeAddr()), typeInfoFor(rv.Type()))
+}
+
+// Comparable 对可比较值 v 计算一个 64 位哈希值，不需要调用方先把 v 手动
+// 序列化成 []byte。两个根据 == 相等的值一定会得到相同的哈希；两个根据
+// == 不相等的值，哈希值大概率不同（和 Hash64 的质量一致）。
+//
+// 这让 chibihash 可以直接作为泛型开放寻址哈希表的哈希函数使用。
+func Comparable[T comparable](seed uint64, v T) uint64 {
+	var h Hasher
+	h.SetSeed(seed)
+	WriteComparable(&h, v)
+	return h.Sum64()
+}
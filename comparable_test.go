@@ -0,0 +1,247 @@
+package chibihash
+
+import (
+	"encoding/binary"
+	"math"
+	"sync"
+	"testing"
+	"unsafe"
+)
+
+func TestComparablePrimitives(t *testing.T) {
+	seed := uint64(0x12345678)
+
+	if Comparable(seed, 42) != Comparable(seed, 42) {
+		t.Error("Comparable not consistent for int")
+	}
+	if Comparable(seed, 42) == Comparable(seed, 43) {
+		t.Error("Comparable should differ for different ints")
+	}
+	if Comparable(seed, "hello") != Comparable(seed, "hello") {
+		t.Error("Comparable not consistent for string")
+	}
+	if Comparable(seed, "hello") == Comparable(seed, "world") {
+		t.Error("Comparable should differ for different strings")
+	}
+	if Comparable(seed, 3.14) == Comparable(seed, 2.71) {
+		t.Error("Comparable should differ for different floats")
+	}
+}
+
+// TestComparableNegativeZero 校验 -0.0 == +0.0 的浮点数（以及内嵌它们的复数）
+// 哈希出相同的值，否则会违反 Comparable 文档里 "== 相等则哈希相等" 的保证，
+// 导致以 +0.0 存入哈希表的键没法用 -0.0 查到。
+func TestComparableNegativeZero(t *testing.T) {
+	seed := uint64(0x12345678)
+
+	posZero32, negZero32 := float32(0), float32(math.Copysign(0, -1))
+	if Comparable(seed, posZero32) != Comparable(seed, negZero32) {
+		t.Error("Comparable should treat float32 +0.0 and -0.0 as equal")
+	}
+
+	posZero64, negZero64 := float64(0), math.Copysign(0, -1)
+	if Comparable(seed, posZero64) != Comparable(seed, negZero64) {
+		t.Error("Comparable should treat float64 +0.0 and -0.0 as equal")
+	}
+
+	posZeroC := complex64(complex(0, 0))
+	negZeroC := complex64(complex(math.Copysign(0, -1), math.Copysign(0, -1)))
+	if Comparable(seed, posZeroC) != Comparable(seed, negZeroC) {
+		t.Error("Comparable should treat complex64 +0.0 and -0.0 components as equal")
+	}
+
+	posZeroC128 := complex(float64(0), float64(0))
+	negZeroC128 := complex(math.Copysign(0, -1), math.Copysign(0, -1))
+	if Comparable(seed, posZeroC128) != Comparable(seed, negZeroC128) {
+		t.Error("Comparable should treat complex128 +0.0 and -0.0 components as equal")
+	}
+}
+
+func TestComparableStringIsLengthPrefixed(t *testing.T) {
+	seed := uint64(0x12345678)
+	s := "The quick brown fox"
+
+	var h Hasher
+	h.SetSeed(seed)
+	var lenBuf [8]byte
+	binary.LittleEndian.PutUint64(lenBuf[:], uint64(len(s)))
+	h.Write(lenBuf[:])
+	h.WriteString(s)
+
+	if got, want := Comparable(seed, s), h.Sum64(); got != want {
+		t.Errorf("Comparable(string) = %x, want %x", got, want)
+	}
+}
+
+type point struct {
+	X, Y int32
+}
+
+type withPadding struct {
+	A byte
+	B int64
+}
+
+func TestComparableStruct(t *testing.T) {
+	seed := uint64(0x12345678)
+
+	if Comparable(seed, point{1, 2}) != Comparable(seed, point{1, 2}) {
+		t.Error("Comparable not consistent for struct")
+	}
+	if Comparable(seed, point{1, 2}) == Comparable(seed, point{2, 1}) {
+		t.Error("Comparable should differ for different struct values")
+	}
+}
+
+type twoStrings struct {
+	A, B string
+}
+
+// TestComparableAdjacentStringFieldsDontAlias 校验两个相邻的变长字符串字段不会
+// 因为简单拼接而产生歧义：{"ab","cd"} 和 {"a","bcd"} 拼接后都是字节串 "abcd"，
+// 如果不在字段之间混入长度，会在任何种子下都得到相同的哈希。
+func TestComparableAdjacentStringFieldsDontAlias(t *testing.T) {
+	seed := uint64(0x12345678)
+
+	a := twoStrings{A: "ab", B: "cd"}
+	b := twoStrings{A: "a", B: "bcd"}
+
+	if a == b {
+		t.Fatal("test fixture bug: a and b should not be ==")
+	}
+	if Comparable(seed, a) == Comparable(seed, b) {
+		t.Error("Comparable should distinguish structurally different adjacent string fields")
+	}
+}
+
+func TestComparableStructIgnoresPadding(t *testing.T) {
+	seed := uint64(0x12345678)
+
+	a := withPadding{A: 1, B: 2}
+	b := withPadding{A: 1, B: 2}
+	// 手工把填充字节改成不同的垃圾值，不应该影响哈希结果。
+	*(*byte)(unsafe.Pointer(uintptr(unsafe.Pointer(&a)) + 1)) = 0xAA
+	*(*byte)(unsafe.Pointer(uintptr(unsafe.Pointer(&b)) + 1)) = 0x55
+
+	if Comparable(seed, a) != Comparable(seed, b) {
+		t.Error("Comparable should ignore struct padding bytes")
+	}
+}
+
+func TestComparableArray(t *testing.T) {
+	seed := uint64(0x12345678)
+
+	a := [4]int{1, 2, 3, 4}
+	b := [4]int{1, 2, 3, 5}
+
+	if Comparable(seed, a) != Comparable(seed, a) {
+		t.Error("Comparable not consistent for array")
+	}
+	if Comparable(seed, a) == Comparable(seed, b) {
+		t.Error("Comparable should differ for different arrays")
+	}
+}
+
+func TestComparablePointer(t *testing.T) {
+	seed := uint64(0x12345678)
+
+	x, y := 1, 1
+	z := 2
+
+	if Comparable(seed, &x) != Comparable(seed, &y) {
+		t.Error("Comparable should hash the pointee, not the pointer address")
+	}
+	if Comparable(seed, &x) == Comparable(seed, &z) {
+		t.Error("Comparable should differ when pointees differ")
+	}
+
+	var nilPtr *int
+	if Comparable(seed, nilPtr) == Comparable(seed, &x) {
+		t.Error("Comparable should distinguish nil pointer from non-nil pointer")
+	}
+}
+
+type cyclicNode struct {
+	Value int
+	Next  *cyclicNode
+}
+
+// TestComparableCyclicPointerDoesNotOverflow 校验数据本身成环（而不仅仅是类型
+// 定义自引用）时 Comparable 不会无限递归、把栈撑爆。
+func TestComparableCyclicPointerDoesNotOverflow(t *testing.T) {
+	seed := uint64(0x12345678)
+
+	n := &cyclicNode{Value: 1}
+	n.Next = n
+
+	got := Comparable(seed, n)
+	if got != Comparable(seed, n) {
+		t.Error("Comparable not consistent for a cyclic pointer value")
+	}
+
+	// 环上的一个不同的 Value 应该产生不同的哈希。
+	m := &cyclicNode{Value: 2}
+	m.Next = m
+	if got == Comparable(seed, m) {
+		t.Error("Comparable should differ when the cyclic value differs")
+	}
+}
+
+func TestComparableInterface(t *testing.T) {
+	seed := uint64(0x12345678)
+
+	var a, b any = 7, 7
+	var c any = 8
+
+	if Comparable(seed, a) != Comparable(seed, b) {
+		t.Error("Comparable not consistent for interface holding equal values")
+	}
+	if Comparable(seed, a) == Comparable(seed, c) {
+		t.Error("Comparable should differ for interfaces holding different values")
+	}
+
+	var nilIface any
+	if Comparable(seed, nilIface) == Comparable(seed, a) {
+		t.Error("Comparable should distinguish nil interface from a non-nil one")
+	}
+}
+
+// concurrentFirstUse 是一个在测试进程中保证首次出现的结构体类型，
+// 用来在 TestComparableConcurrentFirstUse 中触发 typeInfoFor 的并发构建路径。
+type concurrentFirstUse struct {
+	A int64
+	B [3]string
+	C *concurrentFirstUse
+	D struct {
+		E byte
+		F float64
+	}
+}
+
+// TestComparableConcurrentFirstUse 校验许多个 goroutine 同时第一次对同一个
+// 从未哈希过的类型调用 Comparable 时不会因为 typeInfoFor 的缓存竞争而崩溃。
+// 用 -race 运行可以验证 typeInfoCache 的构建过程本身没有数据竞争。
+func TestComparableConcurrentFirstUse(t *testing.T) {
+	const goroutines = 64
+	seed := uint64(0x12345678)
+	// C 保持为 nil：这里只是想让 concurrentFirstUse 的自引用指针类型
+	// 触发 typeInfoFor 的自引用构建路径，并不需要真的构造一个值上的环。
+	v := concurrentFirstUse{A: 1, B: [3]string{"a", "b", "c"}}
+
+	var wg sync.WaitGroup
+	results := make([]uint64, goroutines)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = Comparable(seed, v)
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 1; i < goroutines; i++ {
+		if results[i] != results[0] {
+			t.Errorf("goroutine %d got %x, want %x", i, results[i], results[0])
+		}
+	}
+}
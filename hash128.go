@@ -0,0 +1,36 @@
+package chibihash
+
+// finalMix64Second 是 finalMix64 的独立变体，用来在同一次遍历中派生出第二路
+// 64 位哈希。它复用主循环和 tailMix 产生的四个 lane，但采用不同的跨 lane
+// 乘法配对（相邻 lane 而非相对 lane）以及一组不同的 moremur 常数，
+// 使得 h1 和 h2 之间也能通过雪崩测试（汉明距离约为 32 位），
+// 同时每一路单独看仍然具有与 Hash64 相同的质量。
+func finalMix64Second(seed uint64, h [4]uint64) uint64 {
+	x := seed
+	x ^= h[0] * ((h[1] >> 32) | 1)
+	x ^= h[1] * ((h[2] >> 32) | 1)
+	x ^= h[2] * ((h[3] >> 32) | 1)
+	x ^= h[3] * ((h[0] >> 32) | 1)
+
+	// moremur mixing，常数与 finalMix64 不同
+	x ^= x >> 32
+	x *= 0x9FB21C651E98DF25
+	x ^= x >> 29
+	x *= 0xFF51AFD7ED558CCD
+	x ^= x >> 32
+
+	return x
+}
+
+// Hash128 计算输入数据的两路独立的 64 位哈希值 (h1, h2)，可以拼成一个 128 位哈希，
+// 也可以直接按 Kirsch–Mitzenmacher 的双重哈希技巧使用：h1 + i*h2 (i = 0, 1, 2, ...)
+// 来派生任意多个近似独立的哈希，从而在布隆过滤器、布谷鸟过滤器等概率型数据结构中
+// 避免对同一份 key 调用两次完整的 Hash64。
+//
+// h1 与调用 Hash64(key, seed) 得到的结果完全相同。
+func Hash128(key []byte, seed uint64) (uint64, uint64) {
+	h := [4]uint64{chibiP1, chibiP2, chibiP3, seed}
+	tail := mainLoop(&h, key)
+	h = tailMix(h, uint64(len(key)), tail)
+	return finalMix64(seed, h), finalMix64Second(seed, h)
+}
@@ -0,0 +1,79 @@
+package chibihash
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestHash128MatchesHash64 校验 Hash128 的第一路输出与 Hash64 完全一致。
+func TestHash128MatchesHash64(t *testing.T) {
+	seed := uint64(0x12345678)
+	data := []byte("Hello, World!")
+
+	h1, _ := Hash128(data, seed)
+	want := Hash64(data, seed)
+
+	if h1 != want {
+		t.Errorf("Hash128 h1 = %x, want %x", h1, want)
+	}
+}
+
+// TestHash128Independent 校验两路输出彼此不同，且都对输入敏感。
+func TestHash128Independent(t *testing.T) {
+	seed := uint64(0x12345678)
+	data := []byte("Hello, World!")
+
+	h1, h2 := Hash128(data, seed)
+	if h1 == h2 {
+		t.Error("h1 and h2 should not be equal")
+	}
+
+	otherH1, otherH2 := Hash128([]byte("Hello, World."), seed)
+	if h1 == otherH1 {
+		t.Error("h1 not sensitive to data change")
+	}
+	if h2 == otherH2 {
+		t.Error("h2 not sensitive to data change")
+	}
+}
+
+// TestHash128Avalanche 检查 h1 与 h2 之间近似满足雪崩关系，即汉明距离约为 32 位。
+func TestHash128Avalanche(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping avalanche test in short mode")
+	}
+
+	const samples = 2000
+	total := 0
+
+	for i := 0; i < samples; i++ {
+		data := make([]byte, 1+i%64)
+		rand.Read(data)
+		h1, h2 := Hash128(data, uint64(i))
+		total += calcHammingDistance(h1, h2)
+	}
+
+	avg := float64(total) / float64(samples)
+	if avg < 24 || avg > 40 {
+		t.Errorf("average Hamming distance between h1 and h2 = %.2f, want close to 32", avg)
+	}
+}
+
+// TestHasherSum128MatchesHash128 校验流式 Sum128 与一次性 Hash128 结果相同。
+func TestHasherSum128MatchesHash128(t *testing.T) {
+	seed := uint64(0xDEADBEEF)
+	data := make([]byte, 300)
+	rand.Read(data)
+
+	wantH1, wantH2 := Hash128(data, seed)
+
+	var h Hasher
+	h.SetSeed(seed)
+	h.Write(data[:100])
+	h.Write(data[100:])
+
+	gotH1, gotH2 := h.Sum128()
+	if gotH1 != wantH1 || gotH2 != wantH2 {
+		t.Errorf("Sum128() = (%x, %x), want (%x, %x)", gotH1, gotH2, wantH1, wantH2)
+	}
+}
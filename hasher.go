@@ -0,0 +1,115 @@
+package chibihash
+
+import (
+	"hash"
+	"unsafe"
+)
+
+// Hasher 是 chibihash 的流式实现，支持分块写入数据后再计算哈希值，
+// 用法与 hash/maphash 中的 Hash 类似。零值 Hasher 使用种子 0，
+// 在第一次 Write/Sum64 之前可以随时调用 SetSeed 修改种子。
+type Hasher struct {
+	seed uint64
+	h    [4]uint64
+	n    uint64 // 已写入的总字节数
+	buf  [32]byte
+	nbuf int // buf 中有效的字节数，取值范围 [0, 32)
+
+	initialized bool
+}
+
+// 确保 Hasher 实现了 hash.Hash64 接口。
+var _ hash.Hash64 = (*Hasher)(nil)
+
+// init 在首次使用前惰性地初始化四个 lane，避免零值 Hasher 需要显式构造函数。
+func (d *Hasher) init() {
+	if d.initialized {
+		return
+	}
+	d.h = [4]uint64{chibiP1, chibiP2, chibiP3, d.seed}
+	d.initialized = true
+}
+
+// SetSeed 设置哈希种子并重置内部状态，之后的 Write 调用会基于新种子计算。
+func (d *Hasher) SetSeed(seed uint64) {
+	d.seed = seed
+	d.initialized = false
+	d.n = 0
+	d.nbuf = 0
+	d.init()
+}
+
+// Reset 清空已写入的数据，种子保持不变。
+func (d *Hasher) Reset() {
+	d.initialized = false
+	d.n = 0
+	d.nbuf = 0
+	d.init()
+}
+
+// Size 返回 Sum64 产生的字节数，实现 hash.Hash 接口。
+func (d *Hasher) Size() int { return 8 }
+
+// BlockSize 返回内部主循环一次处理的字节数，实现 hash.Hash 接口。
+func (d *Hasher) BlockSize() int { return 32 }
+
+// Write 将 p 追加到哈希状态中，可以分任意大小的块多次调用，
+// 效果与一次性调用 Hash64(全部数据拼接, seed) 相同。
+func (d *Hasher) Write(p []byte) (n int, err error) {
+	d.init()
+	n = len(p)
+	d.n += uint64(n)
+
+	if d.nbuf > 0 {
+		take := 32 - d.nbuf
+		if take > len(p) {
+			take = len(p)
+		}
+		copy(d.buf[d.nbuf:], p[:take])
+		d.nbuf += take
+		p = p[take:]
+		if d.nbuf < 32 {
+			return n, nil
+		}
+		mainLoop(&d.h, d.buf[:32])
+		d.nbuf = 0
+	}
+
+	p = mainLoop(&d.h, p)
+
+	if len(p) > 0 {
+		d.nbuf = copy(d.buf[:], p)
+	}
+
+	return n, nil
+}
+
+// WriteString 与 Write 等价，但避免把字符串转换为 []byte 时的额外拷贝。
+func (d *Hasher) WriteString(s string) (n int, err error) {
+	// Write 只读取 p，不会修改或保留它，所以可以直接借用字符串的底层字节。
+	return d.Write(unsafe.Slice(unsafe.StringData(s), len(s)))
+}
+
+// Sum64 返回当前已写入数据的哈希值。Sum64 不会修改 Hasher 的状态，
+// 因此可以在继续 Write 之前多次调用。
+func (d *Hasher) Sum64() uint64 {
+	d.init()
+	h := tailMix(d.h, d.n, d.buf[:d.nbuf])
+	return finalMix64(d.seed, h)
+}
+
+// Sum128 返回当前已写入数据的两路 64 位哈希值（合起来 128 位），用法与 Sum64 相同，
+// 具体构造参见 Hash128 的文档。
+func (d *Hasher) Sum128() (uint64, uint64) {
+	d.init()
+	h := tailMix(d.h, d.n, d.buf[:d.nbuf])
+	return finalMix64(d.seed, h), finalMix64Second(d.seed, h)
+}
+
+// Sum 实现 hash.Hash 接口，将 Sum64 的结果以大端序追加到 b 后返回。
+func (d *Hasher) Sum(b []byte) []byte {
+	s := d.Sum64()
+	return append(b,
+		byte(s>>56), byte(s>>48), byte(s>>40), byte(s>>32),
+		byte(s>>24), byte(s>>16), byte(s>>8), byte(s))
+}
@@ -0,0 +1,116 @@
+package chibihash
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestHasherMatchesHash64 校验分块写入与一次性调用 Hash64 得到相同的结果。
+func TestHasherMatchesHash64(t *testing.T) {
+	seed := uint64(0x12345678)
+	data := make([]byte, 4096)
+	rand.Read(data)
+
+	want := Hash64(data, seed)
+
+	var h Hasher
+	h.SetSeed(seed)
+	if _, err := h.Write(data); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if got := h.Sum64(); got != want {
+		t.Errorf("Sum64() = %x, want %x", got, want)
+	}
+}
+
+// TestHasherRandomChunks 用随机大小的分块写入同一份数据多次，
+// 结果都应该与一次性调用 Hash64 一致。
+func TestHasherRandomChunks(t *testing.T) {
+	seed := uint64(0xDEADBEEF)
+	sizes := []int{0, 1, 7, 8, 9, 31, 32, 33, 63, 64, 65, 1000, 4096}
+
+	for _, size := range sizes {
+		data := make([]byte, size)
+		rand.Read(data)
+		want := Hash64(data, seed)
+
+		for attempt := 0; attempt < 10; attempt++ {
+			var h Hasher
+			h.SetSeed(seed)
+
+			remaining := data
+			for len(remaining) > 0 {
+				chunk := rand.Intn(len(remaining)) + 1
+				n, err := h.Write(remaining[:chunk])
+				if err != nil {
+					t.Fatalf("Write returned error: %v", err)
+				}
+				if n != chunk {
+					t.Fatalf("Write returned n=%d, want %d", n, chunk)
+				}
+				remaining = remaining[chunk:]
+			}
+
+			if got := h.Sum64(); got != want {
+				t.Errorf("size=%d attempt=%d: Sum64() = %x, want %x", size, attempt, got, want)
+			}
+		}
+	}
+}
+
+// TestHasherSum64Idempotent 确保多次调用 Sum64 不会互相影响，也不会改变后续 Write 的结果。
+func TestHasherSum64Idempotent(t *testing.T) {
+	seed := uint64(1)
+	data := []byte("Hello, World! This spans more than one block of 32 bytes.")
+
+	var h Hasher
+	h.SetSeed(seed)
+	h.Write(data[:20])
+
+	first := h.Sum64()
+	second := h.Sum64()
+	if first != second {
+		t.Errorf("Sum64() not idempotent: %x != %x", first, second)
+	}
+
+	h.Write(data[20:])
+	want := Hash64(data, seed)
+	if got := h.Sum64(); got != want {
+		t.Errorf("Sum64() after further writes = %x, want %x", got, want)
+	}
+}
+
+// TestHasherWriteString 校验 WriteString 与 Write([]byte(s)) 等价。
+func TestHasherWriteString(t *testing.T) {
+	seed := uint64(42)
+	s := "The quick brown fox jumps over the lazy dog"
+
+	var h1 Hasher
+	h1.SetSeed(seed)
+	h1.WriteString(s)
+
+	var h2 Hasher
+	h2.SetSeed(seed)
+	h2.Write([]byte(s))
+
+	if h1.Sum64() != h2.Sum64() {
+		t.Errorf("WriteString and Write produced different hashes")
+	}
+}
+
+// TestHasherReset 校验 Reset 之后种子保持不变，Hasher 的行为与用同一种子新建的 Hasher 一致。
+func TestHasherReset(t *testing.T) {
+	seed := uint64(7)
+	data := []byte("some data to hash across a reset boundary")
+
+	var h Hasher
+	h.SetSeed(seed)
+	h.Write(data)
+	h.Reset()
+	h.Write(data)
+
+	want := Hash64(data, seed)
+	if got := h.Sum64(); got != want {
+		t.Errorf("Sum64() after Reset = %x, want %x", got, want)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build purego || (!amd64 && !arm64)
+
+package chibihash
+
+// load64le 从字节切片中以小端序加载一个uint64值。
+// 这是可移植的 Go 实现，用于没有专门汇编实现的架构，
+// 或者通过 purego 构建标签显式要求使用纯 Go 代码时。
+func load64le(p []byte) uint64 {
+	return uint64(p[0]) |
+		uint64(p[1])<<8 |
+		uint64(p[2])<<16 |
+		uint64(p[3])<<24 |
+		uint64(p[4])<<32 |
+		uint64(p[5])<<40 |
+		uint64(p[6])<<48 |
+		uint64(p[7])<<56
+}
@@ -0,0 +1,15 @@
+//go:build (amd64 || arm64) && !purego
+
+package chibihash
+
+import "unsafe"
+
+// load64le 从字节切片中以小端序加载一个uint64值。
+// amd64 和 arm64 都是小端序架构（chibihash 不支持大端 arm64），
+// 因此可以直接做一次非对齐的 8 字节加载，而不用逐字节拼接，
+// 在短 key 密集的场景下能明显减少指令数。传入 -tags purego 可以
+// 回退到 loadle_generic.go 中的可移植实现。
+func load64le(p []byte) uint64 {
+	_ = p[7] // 确保 p 至少有 8 个字节，否则下面的 8 字节加载会越界读取
+	return *(*uint64)(unsafe.Pointer(&p[0]))
+}
@@ -0,0 +1,20 @@
+//go:build (amd64 || arm64) && !purego
+
+package chibihash
+
+// mainLoopAsm 是 hash_amd64.s / hash_arm64.s 中实现的汇编版本，处理
+// p[:n] 中所有完整的 32 字节块（即 n/32*32 字节），把结果累加进 h，
+// 返回实际处理掉的字节数。
+//
+//go:noescape
+func mainLoopAsm(h *[4]uint64, p *byte, n int) int
+
+// mainLoop 处理 k 中所有完整的 32 字节块，更新四个 lane，
+// 返回处理完毕后剩余的、不足 32 字节的尾部切片。
+func mainLoop(h *[4]uint64, k []byte) []byte {
+	if len(k) < 32 {
+		return k
+	}
+	n := mainLoopAsm(h, &k[0], len(k))
+	return k[n:]
+}
@@ -0,0 +1,11 @@
+//go:build purego || (!amd64 && !arm64)
+
+package chibihash
+
+// mainLoop 处理 k 中所有完整的 32 字节块，更新四个 lane，
+// 返回处理完毕后剩余的、不足 32 字节的尾部切片。
+// 这是可移植的 Go 实现，amd64/arm64 下默认使用 mainloop_asm.go 中
+// 调用汇编实现的版本，可以通过 -tags purego 强制使用本文件。
+func mainLoop(h *[4]uint64, k []byte) []byte {
+	return mainLoopPureGo(h, k)
+}
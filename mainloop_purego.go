@@ -0,0 +1,21 @@
+package chibihash
+
+// mainLoopPureGo 处理 k 中所有完整的 32 字节块，更新四个 lane，返回处理完毕后
+// 剩余的、不足 32 字节的尾部切片。这是可移植的纯 Go 实现，不带任何构建标签，
+// 因此在 amd64/arm64 上也始终可以编译进来，方便 BenchmarkHash64 把它跟
+// mainLoop（amd64/arm64 下是汇编实现）放在同一次运行里对比速度。
+//
+// mainloop_generic.go 里的 mainLoop（purego 或者非 amd64/arm64 架构下使用）
+// 就是直接转发到这里。
+func mainLoopPureGo(h *[4]uint64, k []byte) []byte {
+	for len(k) >= 32 {
+		for i := 0; i < 4; i++ {
+			lane := load64le(k[i*8:])
+			h[i] ^= lane
+			h[i] *= chibiP1
+			h[(i+1)&3] ^= (lane << 40) | (lane >> 24)
+		}
+		k = k[32:]
+	}
+	return k
+}
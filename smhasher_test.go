@@ -0,0 +1,190 @@
+package chibihash
+
+import (
+	"flag"
+	"math/rand"
+	"testing"
+)
+
+// long 控制是否运行本文件中的 Smhasher 风格质量测试。这些测试通常需要几分钟，
+// 因此默认只在非 -short 模式下运行；传入 -long 可以强制运行，即使同时传了 -short。
+var long = flag.Bool("long", false, "run the slow Smhasher-style hash quality tests even with -short")
+
+// smhasherSeeds 是各质量测试反复使用的一组种子，覆盖 0、常见的小值以及若干随机大数。
+var smhasherSeeds = []uint64{0, 1, 0x2f693b52ce3c9c78, 0x9ae16a3b2f90404f}
+
+// skipIfShort 在 -short 且没有传 -long 时跳过慢速质量测试。
+func skipIfShort(t *testing.T) {
+	t.Helper()
+	if testing.Short() && !*long {
+		t.Skip("skipping Smhasher-style test in short mode (pass -long to force)")
+	}
+}
+
+// collisions 统计 hashes 中两两相同的哈希值对数，用于判断一组 key 是否产生了碰撞。
+func collisions(hashes []uint64) int {
+	seen := make(map[uint64]int, len(hashes))
+	for _, h := range hashes {
+		seen[h]++
+	}
+	n := 0
+	for _, c := range seen {
+		if c > 1 {
+			n += c * (c - 1) / 2
+		}
+	}
+	return n
+}
+
+// sparseKeys 生成长度为 size 位、置位数量在 [0, bits] 之间的所有 key，并返回它们的哈希值。
+func sparseKeys(size, bits int, seed uint64) []uint64 {
+	k := make([]byte, size/8)
+	var hashes []uint64
+
+	var gen func(minBit, nbits int)
+	gen = func(minBit, nbits int) {
+		if nbits == 0 {
+			hashes = append(hashes, Hash64(k, seed))
+			return
+		}
+		for i := minBit; i <= size-nbits; i++ {
+			k[i/8] |= 1 << uint(i%8)
+			gen(i+1, nbits-1)
+			k[i/8] &^= 1 << uint(i%8)
+		}
+	}
+
+	for b := 0; b <= bits; b++ {
+		gen(0, b)
+	}
+	return hashes
+}
+
+// TestSparse 是 Smhasher 的 Sparse 测试：枚举置位数很少的 key，
+// 这类 key 在质量较差的哈希函数中很容易产生碰撞。
+func TestSparse(t *testing.T) {
+	skipIfShort(t)
+
+	sizes := []struct {
+		bits, maxSetBits int
+	}{
+		{32, 4},
+		{40, 4},
+		{48, 3},
+		{56, 3},
+		{64, 3},
+		{96, 2},
+		{160, 2},
+	}
+
+	for _, seed := range smhasherSeeds {
+		for _, s := range sizes {
+			hashes := sparseKeys(s.bits, s.maxSetBits, seed)
+			if c := collisions(hashes); c > 0 {
+				t.Errorf("seed=%#x size=%d bits: %d collisions among %d sparse keys", seed, s.bits, c, len(hashes))
+			}
+		}
+	}
+}
+
+// TestAppendedZeros 校验对同一个 key 依次追加越来越多的 0 字节不会产生碰撞，
+// 也就是说尾部长度信息确实被混入了状态中。
+func TestAppendedZeros(t *testing.T) {
+	skipIfShort(t)
+
+	base := []byte("Testing123")
+
+	for _, seed := range smhasherSeeds {
+		key := append([]byte(nil), base...)
+		hashes := make([]uint64, 0, 256)
+		for i := 0; i < 256; i++ {
+			hashes = append(hashes, Hash64(key, seed))
+			key = append(key, 0)
+		}
+		if c := collisions(hashes); c > 0 {
+			t.Errorf("seed=%#x: %d collisions among %d appended-zero keys", seed, c, len(hashes))
+		}
+	}
+}
+
+// TestWindowed 是 Smhasher 的 Windowed 测试：在一个较长的全零 key 中滑动一个置位窗口，
+// 对每一种窗口宽度，检查窗口的所有位置得到互不相同的哈希值。
+func TestWindowed(t *testing.T) {
+	skipIfShort(t)
+
+	const keyBytes = 32
+	const keyBits = keyBytes * 8
+	const maxWindow = 20
+
+	for _, seed := range smhasherSeeds {
+		for winSize := 1; winSize <= maxWindow; winSize++ {
+			hashes := make([]uint64, 0, keyBits)
+			for start := 0; start < keyBits; start++ {
+				key := make([]byte, keyBytes)
+				for b := 0; b < winSize; b++ {
+					bit := (start + b) % keyBits
+					key[bit/8] |= 1 << uint(bit%8)
+				}
+				hashes = append(hashes, Hash64(key, seed))
+			}
+			if c := collisions(hashes); c > 0 {
+				t.Errorf("seed=%#x window=%d: %d collisions among %d windowed keys", seed, winSize, c, len(hashes))
+			}
+		}
+	}
+}
+
+// TestSmallKey 穷举所有 2 字节 key（共 65536 个），验证 Hash64 不会产生碰撞。
+func TestSmallKey(t *testing.T) {
+	skipIfShort(t)
+
+	for _, seed := range smhasherSeeds {
+		hashes := make([]uint64, 0, 1<<16)
+		var k [2]byte
+		for i := 0; i < 256; i++ {
+			for j := 0; j < 256; j++ {
+				k[0], k[1] = byte(i), byte(j)
+				hashes = append(hashes, Hash64(k[:], seed))
+			}
+		}
+		if c := collisions(hashes); c > 0 {
+			t.Errorf("seed=%#x: %d collisions among all %d two-byte keys", seed, c, len(hashes))
+		}
+	}
+}
+
+// TestAvalanche 是 Smhasher 的 Avalanche 测试：对随机 key 翻转每一个输入位，
+// 统计每个输出位翻转的概率，理想情况下应接近 0.5，测试放宽到 [0.3, 0.7]。
+func TestAvalanche(t *testing.T) {
+	skipIfShort(t)
+
+	const keySize = 32 // bytes
+	const trials = 300
+	seed := uint64(0x12345678)
+
+	for bit := 0; bit < keySize*8; bit++ {
+		var flips [64]int
+		for trial := 0; trial < trials; trial++ {
+			key := make([]byte, keySize)
+			rand.Read(key)
+
+			flipped := make([]byte, keySize)
+			copy(flipped, key)
+			flipped[bit/8] ^= 1 << uint(bit%8)
+
+			diff := Hash64(key, seed) ^ Hash64(flipped, seed)
+			for b := 0; b < 64; b++ {
+				if diff>>uint(b)&1 != 0 {
+					flips[b]++
+				}
+			}
+		}
+
+		for b, c := range flips {
+			p := float64(c) / float64(trials)
+			if p < 0.3 || p > 0.7 {
+				t.Errorf("input bit %d -> output bit %d flips with probability %.3f, want in [0.3, 0.7]", bit, b, p)
+			}
+		}
+	}
+}